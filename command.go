@@ -19,6 +19,7 @@ package gocli
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 )
 
@@ -30,15 +31,42 @@ type Command struct {
 	Help string
 	// Paremeters accepted by command.
 	Parameters []Parameter
+	// Aliases are alternate names this command can be found by, on
+	// equal footing with Name for both exact and prefix matching.
+	Aliases []string
+	// Options are the flags accepted by command, parsed from args
+	// before Run is invoked. Use Flags to read their parsed values.
+	Options []Option
 	// Run is a function that is executed at user call.
-	Run func(cmd *Command, args []string)
+	Run func(env *Env, cmd *Command, args []string)
 	// Load is a function that is executed when a parent command is called.
-	Load func(cmd *Command)
+	Load func(env *Env, cmd *Command)
+	// PersistentPreRun runs before self's own PreRun and Run. It also
+	// runs for every descendant that gets
+	// executed, in root-to-leaf order, making it a good place for
+	// cross-cutting concerns like authentication or transaction setup
+	// that should apply to a whole subtree.
+	PersistentPreRun func(cmd *Command, args []string) error
+	// PreRun runs immediately before Run, after every PersistentPreRun in
+	// the chain has succeeded.
+	PreRun func(cmd *Command, args []string) error
+	// PostRun runs immediately after Run returns.
+	PostRun func(cmd *Command, args []string) error
+	// PersistentPostRun mirrors PersistentPreRun but runs after Run and
+	// PostRun, in leaf-to-root order.
+	PersistentPostRun func(cmd *Command, args []string) error
+	// UsageTemplate overrides the package-wide default template used to
+	// list this command's children. Empty uses the package default.
+	UsageTemplate string
+	// HelpTemplate overrides the package-wide default template used to
+	// describe this command. Empty uses the package default.
+	HelpTemplate string
 
 	childs Commands
 	parent *Command
 	exit   *Command
 	help   *Command
+	flags  *FlagSet
 }
 
 // A Parameter represents a command parameter.
@@ -70,8 +98,129 @@ func (c *Command) AddChild(cmds ...*Command) {
 	}
 }
 
-// Execute an interactive CLI until users exits.
+// Execute runs gocli using the process' standard streams and
+// environment. When os.Args carries arguments beyond the binary name it
+// dispatches them once via ExecuteArgs, like a regular CLI; otherwise it
+// falls back to the interactive REPL. See ExecuteEnv, ExecuteArgsEnv and
+// ExecuteScriptEnv to drive the same logic with a custom Env.
 func (self *Command) Execute() error {
+	env := DefaultEnv()
+	if len(os.Args) > 1 {
+		return self.ExecuteArgsEnv(env, os.Args[1:])
+	}
+	return self.ExecuteEnv(env)
+}
+
+// ExecuteEnv runs an interactive CLI until users exits, reading from
+// env.Stdin and writing to env.Stdout/env.Stderr instead of the
+// process' standard streams.
+func (self *Command) ExecuteEnv(env *Env) error {
+	if err := self.prepareExecute(env); err != nil {
+		return err
+	}
+	self.ensureBuiltins()
+
+	if env.Line != nil {
+		env.Line.SetCompleter(self.completer)
+	}
+
+	for {
+		prompt := self.prompt()
+
+		var input string
+		var err error
+		if env.Line != nil {
+			input, err = env.Line.Prompt(prompt)
+		} else {
+			fmt.Fprint(env.Stdout, prompt)
+			input, err = readString(env.stdinReader())
+		}
+		if err != nil {
+			fmt.Fprintln(env.Stderr, "error:", err.Error())
+			continue
+		}
+
+		exit, err := self.dispatchLine(env, input)
+		if err != nil {
+			return err
+		}
+		if exit {
+			break
+		}
+	}
+
+	return nil
+}
+
+// prompt builds the "parent/child)>" style prompt shown for self.
+func (self *Command) prompt() string {
+	if self.parent == nil {
+		return fmt.Sprintf("%s>", self.Name)
+	}
+
+	prompt := ""
+	RecurseParents(self, func(cmd *Command, first, last bool) bool {
+		if first {
+			prompt = fmt.Sprintf("%s)>", cmd.Name)
+			return false
+		}
+		if last {
+			prompt = fmt.Sprintf("%s(%s", cmd.Name, prompt)
+			return false
+		}
+		prompt = fmt.Sprintf("%s/%s", cmd.Name, prompt)
+		return false
+	})
+	return prompt
+}
+
+// completer returns candidate completions for line, combining self's
+// child command names with the selected child's parameter names. It is
+// driven by the same Find-based walk used by dispatchLine, so
+// completions stay correct as Load dynamically mutates children.
+func (self *Command) completer(line string) []string {
+	fields := strings.Fields(line)
+	trailingSpace := strings.HasSuffix(line, " ")
+
+	if len(fields) == 0 || (len(fields) == 1 && !trailingSpace) {
+		prefix := ""
+		if len(fields) == 1 {
+			prefix = fields[0]
+		}
+
+		var matches []string
+		for _, v := range self.childs {
+			if strings.HasPrefix(v.Name, prefix) {
+				matches = append(matches, v.Name)
+			}
+		}
+		return matches
+	}
+
+	selCmd, err := self.Find(fields[0])
+	if err != nil || selCmd == nil {
+		return nil
+	}
+
+	prefix := ""
+	typed := fields
+	if !trailingSpace {
+		prefix = fields[len(fields)-1]
+		typed = fields[:len(fields)-1]
+	}
+
+	var matches []string
+	for _, p := range selCmd.Parameters {
+		if strings.HasPrefix(p.Name, prefix) {
+			matches = append(matches, strings.Join(append(typed, p.Name), " "))
+		}
+	}
+	return matches
+}
+
+// prepareExecute validates that self is well formed and runs its Load
+// function, if any. It is shared by every Execute* variant.
+func (self *Command) prepareExecute(env *Env) error {
 	if self.Run != nil && len(self.childs) != 0 {
 		return errors.New("Current command should not define an action and has childs")
 	}
@@ -80,12 +229,18 @@ func (self *Command) Execute() error {
 	}
 
 	if self.Load != nil {
-		self.Load(self)
+		self.Load(env, self)
 	}
 	if self.Run == nil && len(self.childs) == 0 {
 		return errors.New("Current command has neither action and childs")
 	}
 
+	return nil
+}
+
+// ensureBuiltins makes sure self has a help and an exit command,
+// reusing the closest ancestor's if one is already registered.
+func (self *Command) ensureBuiltins() {
 	if !RecurseParents(self, func(cmd *Command, first, last bool) bool {
 		if cmd.help != nil {
 			self.help = cmd.help
@@ -106,64 +261,101 @@ func (self *Command) Execute() error {
 	}) {
 		ExitCommand(self)
 	}
+}
 
-	for {
-		if self.parent == nil {
-			fmt.Printf("%s>", self.Name)
-		} else {
-			prompt := ""
-			RecurseParents(self, func(cmd *Command, first, last bool) bool {
-				if first {
-					prompt = fmt.Sprintf("%s)>", cmd.Name)
-					return false
-				}
-				if last {
-					prompt = fmt.Sprintf("%s(%s", cmd.Name, prompt)
-					return false
-				}
-				prompt = fmt.Sprintf("%s/%s", cmd.Name, prompt)
-				return false
-			})
-			fmt.Print(prompt)
-		}
+// dispatchLine parses a single line of input and dispatches it to the
+// matching command, mirroring one iteration of the interactive loop in
+// ExecuteEnv. The returned exit flag reports whether the exit command
+// was selected.
+func (self *Command) dispatchLine(env *Env, input string) (exit bool, err error) {
+	input = strings.Trim(input, " ")
+	if len(input) == 0 {
+		return false, nil
+	}
 
-		input, err := readString()
-		if err != nil {
-			fmt.Println("error:", err.Error())
-			continue
+	args := parseArgs(input)
+	selCmd, err := self.Find(args[0])
+	if err != nil {
+		fmt.Fprintln(env.Stderr, "error:", err.Error())
+		return false, nil
+	}
+	if selCmd == nil {
+		fmt.Fprintf(env.Stdout,
+			"Invalid command, type %s for available commands\n",
+			self.help.Name)
+		return false, nil
+	}
+	if selCmd == self.exit {
+		return true, nil
+	}
+	if selCmd.Run == nil &&
+		selCmd.Load == nil &&
+		len(selCmd.childs) == 0 {
+		return false, errors.New(fmt.Sprintf(
+			"Missing action for %s command", selCmd.Name))
+	}
+	if selCmd.Run == nil {
+		if err := selCmd.ExecuteEnv(env); err != nil {
+			return false, err
 		}
+		return false, nil
+	}
 
-		input = strings.Trim(input, " ")
-		if len(input) == 0 {
-			continue
-		}
+	fs, positional, err := parseOptions(selCmd.Options, args[1:])
+	if err != nil {
+		fmt.Fprintln(env.Stderr, "error:", err.Error())
+		return false, nil
+	}
 
-		args := parseArgs(input)
-		selCmd := self.Find(args[0])
-		if selCmd == nil {
-			fmt.Printf(
-				"Invalid command, type %s for available commands\n",
-				self.help.Name)
-			continue
+	selCmd.flags = fs
+	if err := selCmd.runWithHooks(env, positional); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// runWithHooks invokes self.Run for args, wrapping it with self's
+// lifecycle hooks: the PersistentPreRun of every ancestor and of self
+// runs root-to-leaf, then self.PreRun, then Run, then self.PostRun, then
+// the PersistentPostRun of self and of every ancestor leaf-to-root. It reuses RecurseParents to walk the
+// chain. A hook returning a non-nil error aborts the remaining hooks
+// and, if it happened before Run, Run itself; the error is returned
+// as-is so it surfaces through Execute's return value.
+func (self *Command) runWithHooks(env *Env, args []string) error {
+	var chain Commands
+	RecurseParents(self, func(cmd *Command, first, last bool) bool {
+		chain = append(chain, cmd)
+		return false
+	})
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		if c := chain[i]; c.PersistentPreRun != nil {
+			if err := c.PersistentPreRun(c, args); err != nil {
+				return err
+			}
 		}
-		if selCmd == self.exit {
-			break
+	}
+
+	if self.PreRun != nil {
+		if err := self.PreRun(self, args); err != nil {
+			return err
 		}
-		if selCmd.Run == nil &&
-			selCmd.Load == nil &&
-			len(selCmd.childs) == 0 {
-			return errors.New(fmt.Sprintf(
-				"Missing action for %s command", selCmd.Name))
+	}
+
+	self.Run(env, self, args)
+
+	if self.PostRun != nil {
+		if err := self.PostRun(self, args); err != nil {
+			return err
 		}
-		if selCmd.Run == nil {
-			err = selCmd.Execute()
-			if err != nil {
+	}
+
+	for _, c := range chain {
+		if c.PersistentPostRun != nil {
+			if err := c.PersistentPostRun(c, args); err != nil {
 				return err
 			}
-			continue
 		}
-
-		selCmd.Run(selCmd, args[1:])
 	}
 
 	return nil
@@ -181,15 +373,72 @@ func ExitCommand(parent *Command) *Command {
 	return cmd
 }
 
-// Find finds a command by its name.
-func (self *Command) Find(name string) *Command {
+// Children returns self's child commands.
+func (self *Command) Children() Commands {
+	return self.childs
+}
+
+// EnablePrefixMatching controls whether Find falls back to resolving an
+// unambiguous prefix of a child's name or alias. It defaults to false,
+// matching cobra's cautious default.
+var EnablePrefixMatching = false
+
+// Find finds a command by its name or one of its Aliases. Once
+// EnablePrefixMatching is enabled, a token that exactly matches no
+// child is resolved against the set of children whose name or an alias
+// has it as a prefix: a single candidate is returned, while two or more
+// are reported as an ambiguous command error listing the candidates.
+// Exact matches are always tried first, so an unambiguous full name
+// wins even if it also happens to prefix another child.
+func (self *Command) Find(name string) (*Command, error) {
 	for _, v := range self.childs {
-		if v.Name == name {
-			return v
+		if v.Name == name || containsString(v.Aliases, name) {
+			return v, nil
 		}
 	}
 
-	return nil
+	if !EnablePrefixMatching {
+		return nil, nil
+	}
+
+	var matches Commands
+	for _, v := range self.childs {
+		if strings.HasPrefix(v.Name, name) || hasPrefixString(v.Aliases, name) {
+			matches = append(matches, v)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, v := range matches {
+			names[i] = v.Name
+		}
+		return nil, errors.New(fmt.Sprintf(
+			"Ambiguous command %q, candidates: %s", name, strings.Join(names, ", ")))
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefixString(values []string, prefix string) bool {
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 // HelpCommand creates a new help command and adds it to selected parent
@@ -236,47 +485,44 @@ func RecurseParents(cmd *Command, f RecurseGet) (successful bool) {
 	return false
 }
 
-// DefaultHelp defines a default output for help command.
-func DefaultHelp(cmd *Command, args []string) {
+// DefaultHelp defines a default output for help command. The overview
+// (no argument) is rendered with usageTemplate and a specific command's
+// detail is rendered with helpTemplate, both overridable with
+// SetUsageTemplate/SetHelpTemplate or per-command via
+// Command.UsageTemplate/Command.HelpTemplate.
+func DefaultHelp(env *Env, cmd *Command, args []string) {
 	parent := cmd.parent
 	if len(args) > 1 {
-		fmt.Println("The help command cannot take more than 1 parameter")
+		fmt.Fprintln(env.Stdout, "The help command cannot take more than 1 parameter")
 		return
 	}
+
 	if len(args) == 0 {
-		maxLen := 0
-		for _, v := range parent.childs {
-			if len(v.Name) > maxLen {
-				maxLen = len(v.Name)
-			}
+		tmpl := usageTemplate
+		if parent.UsageTemplate != "" {
+			tmpl = parent.UsageTemplate
 		}
-		fmt.Println(parent.Help, "\n")
-		fmt.Println("Available commands:")
-		fmtStr := fmt.Sprintf("  %%-%ds  %%s\n", maxLen)
-		for _, v := range parent.childs {
-			fmt.Printf(fmtStr, v.Name, v.Help)
-		}
-	} else {
-		selCmd := parent.Find(args[0])
-		if selCmd == nil {
-			fmt.Printf("The command %s cannot be found", args[0])
-			return
+		if err := renderTemplate(env.Stdout, tmpl, parent); err != nil {
+			fmt.Fprintln(env.Stderr, "error:", err.Error())
 		}
+		return
+	}
 
-		fmt.Println(selCmd.Help)
-		if len(selCmd.Parameters) == 0 {
-			return
-		}
-		maxLen := 0
-		for _, v := range selCmd.Parameters {
-			if len(v.Name) > maxLen {
-				maxLen = len(v.Name)
-			}
-		}
-		fmt.Println("\nAvailable parameters:")
-		fmtStr := fmt.Sprintf("  %%-%ds  %%s\n", maxLen)
-		for _, v := range selCmd.Parameters {
-			fmt.Printf(fmtStr, v.Name, v.Help)
-		}
+	selCmd, err := parent.Find(args[0])
+	if err != nil {
+		fmt.Fprintln(env.Stderr, "error:", err.Error())
+		return
+	}
+	if selCmd == nil {
+		fmt.Fprintf(env.Stdout, "The command %s cannot be found", args[0])
+		return
+	}
+
+	tmpl := helpTemplate
+	if selCmd.HelpTemplate != "" {
+		tmpl = selCmd.HelpTemplate
+	}
+	if err := renderTemplate(env.Stdout, tmpl, selCmd); err != nil {
+		fmt.Fprintln(env.Stderr, "error:", err.Error())
 	}
 }
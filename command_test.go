@@ -0,0 +1,130 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// newTestEnv returns an Env backed by in-memory buffers.
+func newTestEnv() *Env {
+	return &Env{
+		Stdin:  &bytes.Buffer{},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+}
+
+func TestExecuteArgsEnvDispatchesToRun(t *testing.T) {
+	var gotArgs []string
+	root := &Command{
+		Name: "root",
+		Help: "root command",
+	}
+	root.AddChild(&Command{
+		Name: "greet",
+		Help: "greet someone",
+		Run: func(env *Env, cmd *Command, args []string) {
+			gotArgs = args
+		},
+	})
+
+	env := newTestEnv()
+	if err := root.ExecuteArgsEnv(env, []string{"greet", "world"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gotArgs) != 1 || gotArgs[0] != "world" {
+		t.Errorf("args = %v, want [world]", gotArgs)
+	}
+}
+
+func TestRunWithHooksOrdering(t *testing.T) {
+	var order []string
+	record := func(name string) func(cmd *Command, args []string) error {
+		return func(cmd *Command, args []string) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	root := &Command{
+		Name:              "root",
+		PersistentPreRun:  record("root.PersistentPreRun"),
+		PersistentPostRun: record("root.PersistentPostRun"),
+	}
+	child := &Command{
+		Name:              "child",
+		PersistentPreRun:  record("child.PersistentPreRun"),
+		PersistentPostRun: record("child.PersistentPostRun"),
+	}
+	leaf := &Command{
+		Name:     "leaf",
+		PreRun:   record("leaf.PreRun"),
+		PostRun:  record("leaf.PostRun"),
+		Run: func(env *Env, cmd *Command, args []string) {
+			order = append(order, "leaf.Run")
+		},
+	}
+	root.AddChild(child)
+	child.AddChild(leaf)
+
+	if err := leaf.runWithHooks(newTestEnv(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"root.PersistentPreRun",
+		"child.PersistentPreRun",
+		"leaf.PreRun",
+		"leaf.Run",
+		"leaf.PostRun",
+		"child.PersistentPostRun",
+		"root.PersistentPostRun",
+	}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("order[%d] = %q, want %q (full: %v)", i, order[i], want[i], order)
+		}
+	}
+}
+
+func TestRunWithHooksAbortsOnError(t *testing.T) {
+	ranRun := false
+	boom := errors.New("boom")
+	leaf := &Command{
+		Name: "leaf",
+		PreRun: func(cmd *Command, args []string) error {
+			return boom
+		},
+		Run: func(env *Env, cmd *Command, args []string) {
+			ranRun = true
+		},
+	}
+
+	err := leaf.runWithHooks(newTestEnv(), nil)
+	if err != boom {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if ranRun {
+		t.Error("Run was invoked despite PreRun returning an error")
+	}
+}
@@ -0,0 +1,91 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCompleterChildNames(t *testing.T) {
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "status"})
+	root.AddChild(&Command{Name: "stop"})
+	root.AddChild(&Command{Name: "get"})
+
+	matches := root.completer("st")
+	sort.Strings(matches)
+	want := []string{"status", "stop"}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("completer(%q) = %v, want %v", "st", matches, want)
+	}
+}
+
+func TestCompleterChildNamesEmptyLine(t *testing.T) {
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "status"})
+
+	matches := root.completer("")
+	if len(matches) != 1 || matches[0] != "status" {
+		t.Errorf("completer(%q) = %v, want [status]", "", matches)
+	}
+}
+
+func TestCompleterParameterNames(t *testing.T) {
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{
+		Name: "get",
+		Parameters: []Parameter{
+			{Name: "id"},
+			{Name: "index"},
+		},
+		Run: func(env *Env, cmd *Command, args []string) {},
+	})
+
+	matches := root.completer("get i")
+	sort.Strings(matches)
+	want := []string{"get id", "get index"}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("completer(%q) = %v, want %v", "get i", matches, want)
+	}
+}
+
+func TestCompleterParameterNamesTrailingSpace(t *testing.T) {
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{
+		Name: "get",
+		Parameters: []Parameter{
+			{Name: "id"},
+		},
+		Run: func(env *Env, cmd *Command, args []string) {},
+	})
+
+	matches := root.completer("get ")
+	if len(matches) != 1 || matches[0] != "get id" {
+		t.Errorf("completer(%q) = %v, want [get id]", "get ", matches)
+	}
+}
+
+func TestCompleterUnknownCommand(t *testing.T) {
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "get"})
+
+	if matches := root.completer("bogus arg"); matches != nil {
+		t.Errorf("completer(%q) = %v, want nil", "bogus arg", matches)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// An Env carries the IO streams and environment-like variables a Command
+// is executed with.
+type Env struct {
+	// Stdin is the reader used to obtain user input.
+	Stdin io.Reader
+	// Stdout is the writer used for regular output.
+	Stdout io.Writer
+	// Stderr is the writer used for error output.
+	Stderr io.Writer
+	// Vars holds environment-like key/value pairs available to commands.
+	Vars map[string]string
+	// Line, when set, is used by ExecuteEnv to read interactive input
+	// with history and tab completion instead of the plain Stdin
+	// reader. Callers own its lifecycle and should Close it once done.
+	Line LineReader
+
+	reader *bufio.Reader
+}
+
+// DefaultEnv returns an Env backed by the process' standard streams and
+// its environment variables.
+func DefaultEnv() *Env {
+	vars := make(map[string]string)
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) == 2 {
+			vars[parts[0]] = parts[1]
+		}
+	}
+
+	return &Env{
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		Vars:   vars,
+	}
+}
+
+// stdinReader returns a buffered reader over env.Stdin, reusing it across
+// calls so buffered input is not lost between reads.
+func (env *Env) stdinReader() *bufio.Reader {
+	if env.reader == nil {
+		env.reader = bufio.NewReader(env.Stdin)
+	}
+
+	return env.reader
+}
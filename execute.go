@@ -0,0 +1,111 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ExecuteArgs walks the command tree once using the given argv and
+// dispatches to the matching leaf Run, using the process' standard
+// streams and environment. Unlike Execute it never prompts, which makes
+// gocli usable as a regular, non-interactive CLI binary.
+func (self *Command) ExecuteArgs(args []string) error {
+	return self.ExecuteArgsEnv(DefaultEnv(), args)
+}
+
+// ExecuteArgsEnv is the Env-aware counterpart of ExecuteArgs.
+func (self *Command) ExecuteArgsEnv(env *Env, args []string) error {
+	if err := self.prepareExecute(env); err != nil {
+		return err
+	}
+	self.ensureBuiltins()
+
+	if self.Run != nil {
+		fs, positional, err := parseOptions(self.Options, args)
+		if err != nil {
+			return err
+		}
+
+		self.flags = fs
+		return self.runWithHooks(env, positional)
+	}
+
+	if len(args) == 0 {
+		return errors.New(fmt.Sprintf("Missing arguments for %s command", self.Name))
+	}
+
+	selCmd, err := self.Find(args[0])
+	if err != nil {
+		return err
+	}
+	if selCmd == nil {
+		return errors.New(fmt.Sprintf("Invalid command: %s", args[0]))
+	}
+	if selCmd == self.exit {
+		return nil
+	}
+	if selCmd.Run == nil &&
+		selCmd.Load == nil &&
+		len(selCmd.childs) == 0 {
+		return errors.New(fmt.Sprintf(
+			"Missing action for %s command", selCmd.Name))
+	}
+	if selCmd.Run == nil {
+		return selCmd.ExecuteArgsEnv(env, args[1:])
+	}
+
+	fs, positional, err := parseOptions(selCmd.Options, args[1:])
+	if err != nil {
+		return err
+	}
+
+	selCmd.flags = fs
+	return selCmd.runWithHooks(env, positional)
+}
+
+// ExecuteScript reads one command per line from r and dispatches each
+// through the same code path used by the interactive REPL, using the
+// process' standard streams and environment. It stops early if a line
+// selects the exit command.
+func (self *Command) ExecuteScript(r io.Reader) error {
+	return self.ExecuteScriptEnv(DefaultEnv(), r)
+}
+
+// ExecuteScriptEnv is the Env-aware counterpart of ExecuteScript.
+func (self *Command) ExecuteScriptEnv(env *Env, r io.Reader) error {
+	if err := self.prepareExecute(env); err != nil {
+		return err
+	}
+	self.ensureBuiltins()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		exit, err := self.dispatchLine(env, scanner.Text())
+		if err != nil {
+			return err
+		}
+		if exit {
+			break
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,112 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import "testing"
+
+func TestFindExactMatchWinsOverPrefix(t *testing.T) {
+	EnablePrefixMatching = true
+	defer func() { EnablePrefixMatching = false }()
+
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "get"})
+	root.AddChild(&Command{Name: "getall"})
+
+	cmd, err := root.Find("get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd == nil || cmd.Name != "get" {
+		t.Fatalf("got %v, want exact match on %q", cmd, "get")
+	}
+}
+
+func TestFindPrefixDisabledByDefault(t *testing.T) {
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "status"})
+
+	cmd, err := root.Find("stat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd != nil {
+		t.Fatalf("got %v, want nil since EnablePrefixMatching is false", cmd)
+	}
+}
+
+func TestFindUnambiguousPrefix(t *testing.T) {
+	EnablePrefixMatching = true
+	defer func() { EnablePrefixMatching = false }()
+
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "status"})
+	root.AddChild(&Command{Name: "stop"})
+
+	cmd, err := root.Find("sta")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd == nil || cmd.Name != "status" {
+		t.Fatalf("got %v, want %q", cmd, "status")
+	}
+}
+
+func TestFindAmbiguousPrefix(t *testing.T) {
+	EnablePrefixMatching = true
+	defer func() { EnablePrefixMatching = false }()
+
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "status"})
+	root.AddChild(&Command{Name: "stop"})
+
+	cmd, err := root.Find("st")
+	if cmd != nil {
+		t.Fatalf("got %v, want nil on ambiguous prefix", cmd)
+	}
+	if err == nil {
+		t.Fatal("expected ambiguous command error, got nil")
+	}
+}
+
+func TestFindAlias(t *testing.T) {
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "remove", Aliases: []string{"rm"}})
+
+	cmd, err := root.Find("rm")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd == nil || cmd.Name != "remove" {
+		t.Fatalf("got %v, want alias match on %q", cmd, "remove")
+	}
+}
+
+func TestFindAliasPrefix(t *testing.T) {
+	EnablePrefixMatching = true
+	defer func() { EnablePrefixMatching = false }()
+
+	root := &Command{Name: "root"}
+	root.AddChild(&Command{Name: "remove", Aliases: []string{"delete"}})
+
+	cmd, err := root.Find("del")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd == nil || cmd.Name != "remove" {
+		t.Fatalf("got %v, want prefix match via alias on %q", cmd, "remove")
+	}
+}
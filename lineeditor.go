@@ -0,0 +1,101 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import (
+	"os"
+
+	"github.com/peterh/liner"
+)
+
+// A LineReader abstracts interactive line editing, so Command.ExecuteEnv
+// can offer history and tab completion without hardcoding a specific
+// terminal library. Env.Line is nil by default, in which case
+// ExecuteEnv falls back to plain, non-editable input.
+type LineReader interface {
+	// Prompt displays prompt and reads a single line of input.
+	Prompt(prompt string) (string, error)
+	// SetCompleter installs f as the tab-completion callback. f receives
+	// the line typed so far and returns the candidate completions.
+	SetCompleter(f func(line string) []string)
+	// Close releases any resources held by the reader, persisting
+	// history to disk first if a history file was configured.
+	Close() error
+}
+
+// linerReader is the default LineReader, backed by
+// github.com/peterh/liner.
+type linerReader struct {
+	state       *liner.State
+	historyFile string
+}
+
+// NewLineReader returns the default LineReader, with history persisted
+// to historyFile between sessions. An empty historyFile disables
+// history persistence.
+func NewLineReader(historyFile string) (LineReader, error) {
+	state := liner.NewLiner()
+	state.SetCtrlCAborts(true)
+
+	r := &linerReader{state: state, historyFile: historyFile}
+
+	if historyFile != "" {
+		if f, err := os.Open(historyFile); err == nil {
+			state.ReadHistory(f)
+			f.Close()
+		}
+	}
+
+	return r, nil
+}
+
+// Prompt implements LineReader.
+func (r *linerReader) Prompt(prompt string) (string, error) {
+	line, err := r.state.Prompt(prompt)
+	if err != nil {
+		return line, err
+	}
+
+	if line != "" {
+		r.state.AppendHistory(line)
+	}
+
+	return line, nil
+}
+
+// SetCompleter implements LineReader.
+func (r *linerReader) SetCompleter(f func(line string) []string) {
+	r.state.SetCompleter(f)
+}
+
+// Close implements LineReader.
+func (r *linerReader) Close() error {
+	defer r.state.Close()
+
+	if r.historyFile == "" {
+		return nil
+	}
+
+	f, err := os.Create(r.historyFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = r.state.WriteHistory(f)
+	return err
+}
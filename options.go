@@ -0,0 +1,246 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An Option represents a command-line flag accepted by a Command, as
+// opposed to a Parameter, which only documents a positional argument.
+type Option struct {
+	// Short is the flag's one-letter form, e.g. 'v' for -v. Zero means
+	// the option has no short form.
+	Short rune
+	// Long is the flag's long form, e.g. "verbose" for --verbose.
+	Long string
+	// Help describes the option for DefaultHelp.
+	Help string
+	// Type is the option's value type: "string", "int", "bool",
+	// "float", "duration" or "stringSlice".
+	Type string
+	// Default is used when the option is not supplied.
+	Default string
+	// Required marks an option that must be supplied before Run is
+	// invoked.
+	Required bool
+}
+
+// A FlagSet holds the parsed values of a Command's Options for the
+// current invocation.
+type FlagSet struct {
+	values map[string]string
+	set    map[string]bool
+}
+
+func newFlagSet(opts []Option) *FlagSet {
+	fs := &FlagSet{
+		values: make(map[string]string, len(opts)),
+		set:    make(map[string]bool, len(opts)),
+	}
+	for _, o := range opts {
+		fs.values[o.Long] = o.Default
+	}
+
+	return fs
+}
+
+// String returns the value of the named option.
+func (fs *FlagSet) String(long string) string {
+	return fs.values[long]
+}
+
+// Int returns the value of the named option parsed as an int, or 0 if
+// it is not a valid integer.
+func (fs *FlagSet) Int(long string) int {
+	v, _ := strconv.Atoi(fs.values[long])
+	return v
+}
+
+// Bool returns the value of the named option parsed as a bool, or false
+// if it is not a valid boolean.
+func (fs *FlagSet) Bool(long string) bool {
+	v, _ := strconv.ParseBool(fs.values[long])
+	return v
+}
+
+// Float64 returns the value of the named option parsed as a float64, or
+// 0 if it is not a valid float.
+func (fs *FlagSet) Float64(long string) float64 {
+	v, _ := strconv.ParseFloat(fs.values[long], 64)
+	return v
+}
+
+// Duration returns the value of the named option parsed as a
+// time.Duration, or 0 if it is not a valid duration.
+func (fs *FlagSet) Duration(long string) time.Duration {
+	v, _ := time.ParseDuration(fs.values[long])
+	return v
+}
+
+// StringSlice returns the value of the named option split on commas, or
+// nil if that value is empty.
+func (fs *FlagSet) StringSlice(long string) []string {
+	if fs.values[long] == "" {
+		return nil
+	}
+	return strings.Split(fs.values[long], ",")
+}
+
+// Changed reports whether the named option was explicitly supplied.
+func (fs *FlagSet) Changed(long string) bool {
+	return fs.set[long]
+}
+
+// Flags returns the Options parsed for the current invocation of self.
+// It is only meaningful from inside Run, after dispatch has parsed the
+// command line.
+func (self *Command) Flags() *FlagSet {
+	if self.flags == nil {
+		return newFlagSet(self.Options)
+	}
+	return self.flags
+}
+
+func findOption(opts []Option, long string) *Option {
+	for i := range opts {
+		if opts[i].Long == long {
+			return &opts[i]
+		}
+	}
+	return nil
+}
+
+func findOptionByShort(opts []Option, short rune) *Option {
+	for i := range opts {
+		if opts[i].Short == short {
+			return &opts[i]
+		}
+	}
+	return nil
+}
+
+// parseOptions consumes `--long=val`, `--long val`, `-s val`, `-sval`
+// and bundled bool shorts `-abc` out of args, matching them against
+// opts. It returns the parsed flags together with the remaining
+// positional arguments, in their original order, or an error if an
+// unknown option, a missing value or a missing required option is
+// found.
+func parseOptions(opts []Option, args []string) (*FlagSet, []string, error) {
+	fs := newFlagSet(opts)
+	positional := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		tok := args[i]
+
+		switch {
+		case strings.HasPrefix(tok, "--"):
+			name := tok[2:]
+			value := ""
+			hasValue := false
+			if eq := strings.IndexByte(name, '='); eq >= 0 {
+				value = name[eq+1:]
+				name = name[:eq]
+				hasValue = true
+			}
+
+			opt := findOption(opts, name)
+			if opt == nil {
+				return nil, nil, errors.New(fmt.Sprintf("Unknown option: --%s", name))
+			}
+
+			if opt.Type == "bool" && !hasValue {
+				fs.values[opt.Long] = "true"
+				fs.set[opt.Long] = true
+				continue
+			}
+			if !hasValue {
+				i++
+				if i >= len(args) {
+					return nil, nil, errors.New(fmt.Sprintf("Missing value for option --%s", opt.Long))
+				}
+				value = args[i]
+			}
+
+			fs.values[opt.Long] = value
+			fs.set[opt.Long] = true
+
+		case strings.HasPrefix(tok, "-") && len(tok) > 1:
+			letters := tok[1:]
+			for len(letters) > 0 {
+				short := rune(letters[0])
+				opt := findOptionByShort(opts, short)
+				if opt == nil {
+					return nil, nil, errors.New(fmt.Sprintf("Unknown option: -%c", short))
+				}
+
+				rest := letters[1:]
+				if opt.Type == "bool" {
+					fs.values[opt.Long] = "true"
+					fs.set[opt.Long] = true
+					letters = rest
+					continue
+				}
+				if rest != "" {
+					fs.values[opt.Long] = rest
+					fs.set[opt.Long] = true
+					break
+				}
+
+				i++
+				if i >= len(args) {
+					return nil, nil, errors.New(fmt.Sprintf("Missing value for option -%c", short))
+				}
+				fs.values[opt.Long] = args[i]
+				fs.set[opt.Long] = true
+				break
+			}
+
+		default:
+			positional = append(positional, tok)
+		}
+	}
+
+	for _, o := range opts {
+		if o.Required && !fs.set[o.Long] {
+			return nil, nil, errors.New(fmt.Sprintf("Missing required option: --%s", o.Long))
+		}
+	}
+
+	return fs, positional, nil
+}
+
+// optionLabel formats o's short and long forms for DefaultHelp.
+func optionLabel(o Option) string {
+	if o.Short != 0 {
+		return fmt.Sprintf("-%c, --%s", o.Short, o.Long)
+	}
+	return fmt.Sprintf("--%s", o.Long)
+}
+
+// optionDescription formats o's help text together with its type and
+// default value for DefaultHelp.
+func optionDescription(o Option) string {
+	if o.Default == "" {
+		return fmt.Sprintf("%s (%s)", o.Help, o.Type)
+	}
+	return fmt.Sprintf("%s (%s, default: %s)", o.Help, o.Type, o.Default)
+}
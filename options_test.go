@@ -0,0 +1,124 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import "testing"
+
+func TestParseOptionsLongForms(t *testing.T) {
+	opts := []Option{
+		{Long: "name", Type: "string"},
+		{Long: "count", Type: "int"},
+	}
+
+	fs, positional, err := parseOptions(opts, []string{"--name=alice", "--count", "3", "rest"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fs.String("name"); got != "alice" {
+		t.Errorf("name = %q, want %q", got, "alice")
+	}
+	if got := fs.Int("count"); got != 3 {
+		t.Errorf("count = %d, want %d", got, 3)
+	}
+	if len(positional) != 1 || positional[0] != "rest" {
+		t.Errorf("positional = %v, want [rest]", positional)
+	}
+}
+
+func TestParseOptionsShortForms(t *testing.T) {
+	opts := []Option{
+		{Short: 's', Long: "size", Type: "string"},
+	}
+
+	// -sval (bundled value) and -s val (separate value) should behave
+	// the same way.
+	fs, _, err := parseOptions(opts, []string{"-sbig"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fs.String("size"); got != "big" {
+		t.Errorf("-sval: size = %q, want %q", got, "big")
+	}
+
+	fs, _, err = parseOptions(opts, []string{"-s", "big"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fs.String("size"); got != "big" {
+		t.Errorf("-s val: size = %q, want %q", got, "big")
+	}
+}
+
+func TestParseOptionsBundledBoolShorts(t *testing.T) {
+	opts := []Option{
+		{Short: 'a', Long: "a", Type: "bool"},
+		{Short: 'b', Long: "b", Type: "bool"},
+		{Short: 'c', Long: "c", Type: "bool"},
+	}
+
+	fs, _, err := parseOptions(opts, []string{"-abc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, long := range []string{"a", "b", "c"} {
+		if !fs.Bool(long) {
+			t.Errorf("%s = false, want true", long)
+		}
+		if !fs.Changed(long) {
+			t.Errorf("Changed(%s) = false, want true", long)
+		}
+	}
+}
+
+func TestParseOptionsUnknownOption(t *testing.T) {
+	_, _, err := parseOptions(nil, []string{"--missing"})
+	if err == nil {
+		t.Fatal("expected error for unknown long option, got nil")
+	}
+
+	_, _, err = parseOptions(nil, []string{"-x"})
+	if err == nil {
+		t.Fatal("expected error for unknown short option, got nil")
+	}
+}
+
+func TestParseOptionsMissingValue(t *testing.T) {
+	opts := []Option{{Long: "name", Type: "string"}}
+
+	_, _, err := parseOptions(opts, []string{"--name"})
+	if err == nil {
+		t.Fatal("expected error for missing long option value, got nil")
+	}
+
+	opts = []Option{{Short: 'n', Long: "name", Type: "string"}}
+	_, _, err = parseOptions(opts, []string{"-n"})
+	if err == nil {
+		t.Fatal("expected error for missing short option value, got nil")
+	}
+}
+
+func TestParseOptionsRequired(t *testing.T) {
+	opts := []Option{{Long: "name", Type: "string", Required: true}}
+
+	if _, _, err := parseOptions(opts, nil); err == nil {
+		t.Fatal("expected error for missing required option, got nil")
+	}
+
+	if _, _, err := parseOptions(opts, []string{"--name=alice"}); err != nil {
+		t.Fatalf("unexpected error once required option is supplied: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+* Copyright 2015 Fabrício Godoy
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package gocli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var templateFuncs = template.FuncMap{
+	"trim":               strings.TrimSpace,
+	"trimRightSpace":     trimRightSpace,
+	"appendIfNotPresent": appendIfNotPresent,
+	"rpad":               rpad,
+	"maxNameLen":         maxNameLen,
+	"maxParamLen":        maxParamLen,
+	"maxOptionLabelLen":  maxOptionLabelLen,
+	"optionLabel":        optionLabel,
+	"optionDescription":  optionDescription,
+}
+
+// usageTemplate is the package-wide default used to list a command's
+// children, overridable with SetUsageTemplate or per-command via
+// Command.UsageTemplate.
+var usageTemplate = `{{.Help}}
+
+Available commands:
+{{$w := maxNameLen .Children}}{{range .Children}}  {{rpad .Name $w}}  {{.Help}}
+{{end}}`
+
+// helpTemplate is the package-wide default used to describe a single
+// command, overridable with SetHelpTemplate or per-command via
+// Command.HelpTemplate.
+var helpTemplate = `{{.Help}}
+{{if .Parameters}}
+Available parameters:
+{{$w := maxParamLen .Parameters}}{{range .Parameters}}  {{rpad .Name $w}}  {{.Help}}
+{{end}}{{end}}{{if .Options}}
+Available options:
+{{$w := maxOptionLabelLen .Options}}{{range .Options}}  {{rpad (optionLabel .) $w}}  {{optionDescription .}}
+{{end}}{{end}}`
+
+// SetUsageTemplate overrides the package-wide default template DefaultHelp
+// uses to list a command's children. A per-command Command.UsageTemplate
+// takes precedence over this default.
+func SetUsageTemplate(t string) {
+	usageTemplate = t
+}
+
+// SetHelpTemplate overrides the package-wide default template DefaultHelp
+// uses to describe a single command. A per-command Command.HelpTemplate
+// takes precedence over this default.
+func SetHelpTemplate(t string) {
+	helpTemplate = t
+}
+
+// AddTemplateFunc registers fn under name so usage and help templates
+// can call it.
+func AddTemplateFunc(name string, fn interface{}) {
+	templateFuncs[name] = fn
+}
+
+func trimRightSpace(s string) string {
+	return strings.TrimRightFunc(s, unicode.IsSpace)
+}
+
+func rpad(s string, padding int) string {
+	return fmt.Sprintf(fmt.Sprintf("%%-%ds", padding), s)
+}
+
+func appendIfNotPresent(s, stringToAppend string) string {
+	if strings.Contains(s, stringToAppend) {
+		return s
+	}
+	return s + " " + stringToAppend
+}
+
+func maxNameLen(cmds Commands) int {
+	maxLen := 0
+	for _, v := range cmds {
+		if len(v.Name) > maxLen {
+			maxLen = len(v.Name)
+		}
+	}
+	return maxLen
+}
+
+func maxParamLen(params []Parameter) int {
+	maxLen := 0
+	for _, v := range params {
+		if len(v.Name) > maxLen {
+			maxLen = len(v.Name)
+		}
+	}
+	return maxLen
+}
+
+func maxOptionLabelLen(opts []Option) int {
+	maxLen := 0
+	for _, o := range opts {
+		if len(optionLabel(o)) > maxLen {
+			maxLen = len(optionLabel(o))
+		}
+	}
+	return maxLen
+}
+
+// renderTemplate parses tmpl with templateFuncs and executes it against
+// data, writing the result to w.
+func renderTemplate(w io.Writer, tmpl string, data interface{}) error {
+	t, err := template.New("").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	return t.Execute(w, data)
+}
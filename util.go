@@ -18,7 +18,6 @@ package gocli
 
 import (
 	"bufio"
-	"os"
 	"regexp"
 	"strings"
 )
@@ -33,9 +32,8 @@ func init() {
 	rArgs, _ = regexp.Compile(PARAMETERS_PATTERN)
 }
 
-func readString() (string, error) {
-	stdin := bufio.NewReader(os.Stdin)
-	in, err := stdin.ReadString('\n')
+func readString(r *bufio.Reader) (string, error) {
+	in, err := r.ReadString('\n')
 	if err != nil {
 		return "", err
 	}